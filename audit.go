@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"guardian/vpc-nag/nagerr"
+	"guardian/vpc-nag/policy"
+	"guardian/vpc-nag/prism"
+	"guardian/vpc-nag/report"
+)
+
+// auditJob is one (account, region) pair to audit.
+type auditJob struct {
+	accountID string
+	region    string
+}
+
+// resolveAccounts builds the set of account IDs to audit from the -accountID,
+// -accountsFile and -allAccounts flags. -allAccounts, when set, takes every
+// account seen in vpcs and ignores the other two flags; otherwise the
+// -accountID values and the lines of -accountsFile are combined.
+func resolveAccounts(accountIDs []string, accountsFile string, allAccounts bool, vpcs []prism.VPC) ([]string, error) {
+	if allAccounts {
+		seen := map[string]struct{}{}
+		var accounts []string
+		for _, vpc := range vpcs {
+			if _, ok := seen[vpc.AccountID]; ok {
+				continue
+			}
+			seen[vpc.AccountID] = struct{}{}
+			accounts = append(accounts, vpc.AccountID)
+		}
+		return accounts, nil
+	}
+
+	accounts := append([]string{}, accountIDs...)
+
+	if accountsFile != "" {
+		fromFile, err := readLines(accountsFile)
+		if err != nil {
+			return nil, nagerr.Wrap(nagerr.ValidationFailed, "reading accounts file "+accountsFile, err)
+		}
+		accounts = append(accounts, fromFile...)
+	}
+
+	return accounts, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+func parseRegions(regions string) []string {
+	var out []string
+	for _, region := range strings.Split(regions, ",") {
+		region = strings.TrimSpace(region)
+		if region != "" {
+			out = append(out, region)
+		}
+	}
+	return out
+}
+
+// validateConcurrency rejects non-positive worker counts: passed straight
+// into make(chan struct{}, concurrency), zero deadlocks runAudits forever
+// and negative values panic.
+func validateConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return nagerr.New(nagerr.ValidationFailed, fmt.Sprintf("-concurrency must be at least 1, got %d", concurrency))
+	}
+	return nil
+}
+
+// runAudits audits every (account, region) job concurrently, bounded by
+// concurrency workers, and returns the aggregated results in job order.
+// concurrency must be at least 1; validate it with validateConcurrency
+// before calling.
+func runAudits(jobs []auditJob, vpcs []prism.VPC, pol *policy.Policy, concurrency int) []report.Result {
+	resultsByJob := make([][]report.Result, len(jobs))
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job auditJob) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			resultsByJob[i] = auditAccountRegion(job, vpcs, pol)
+		}(i, job)
+	}
+
+	wg.Wait()
+
+	var results []report.Result
+	for _, r := range resultsByJob {
+		results = append(results, r...)
+	}
+	return results
+}
+
+// auditAccountRegion checks compliance for every VPC belonging to
+// job.accountID in job.region.
+func auditAccountRegion(job auditJob, vpcs []prism.VPC, pol *policy.Policy) []report.Result {
+	inScope := Filter(vpcs, func(vpc prism.VPC) bool {
+		return vpc.AccountID == job.accountID && vpc.Meta.Origin.Region == job.region
+	})
+
+	rules := pol.RulesFor(job.accountID)
+
+	results := make([]report.Result, len(inScope))
+	for i, vpc := range inScope {
+		findings := checkCompliance(vpc, rules)
+		results[i] = report.Result{
+			VPCID:     vpc.VPCID,
+			AccountID: vpc.AccountID,
+			Region:    vpc.Meta.Origin.Region,
+			Passed:    len(findings) == 0,
+			Findings:  findings,
+		}
+	}
+	return results
+}
+
+// hasErrorFindings reports whether any result contains an error-severity
+// finding.
+func hasErrorFindings(results []report.Result) bool {
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			if finding.Severity == policy.SeverityError {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"guardian/vpc-nag/nagerr"
+	"guardian/vpc-nag/policy"
+	"guardian/vpc-nag/prism"
+)
+
+func TestValidateConcurrency(t *testing.T) {
+	tests := []struct {
+		concurrency int
+		wantErr     bool
+	}{
+		{concurrency: -1, wantErr: true},
+		{concurrency: 0, wantErr: true},
+		{concurrency: 1, wantErr: false},
+		{concurrency: 8, wantErr: false},
+	}
+
+	for _, tc := range tests {
+		err := validateConcurrency(tc.concurrency)
+		if tc.wantErr {
+			var nerr *nagerr.NagError
+			if !errors.As(err, &nerr) || nerr.Code != nagerr.ValidationFailed {
+				t.Errorf("validateConcurrency(%d) = %v, want a nagerr.ValidationFailed error", tc.concurrency, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("validateConcurrency(%d) = %v, want nil", tc.concurrency, err)
+		}
+	}
+}
+
+func TestParseRegions(t *testing.T) {
+	got := parseRegions(" eu-west-1, us-east-1 ,,eu-west-2")
+	want := []string{"eu-west-1", "us-east-1", "eu-west-2"}
+
+	if len(got) != len(want) {
+		t.Fatalf("parseRegions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseRegions() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRunAuditsAggregatesAcrossJobsConcurrently(t *testing.T) {
+	vpcs := []prism.VPC{
+		{VPCID: "vpc-1", AccountID: "111", IsDefault: true},
+		{VPCID: "vpc-2", AccountID: "222", IsDefault: true},
+		{VPCID: "vpc-3", AccountID: "333", IsDefault: true},
+	}
+
+	jobs := []auditJob{
+		{accountID: "111", region: "eu-west-1"},
+		{accountID: "222", region: "eu-west-1"},
+		{accountID: "333", region: "eu-west-1"},
+	}
+
+	// The region lives in the nested Meta field, so set it here; the job's
+	// region only filters which VPCs are in scope, and the default policy
+	// flags each of these default VPCs.
+	for i := range vpcs {
+		vpcs[i].Meta.Origin.Region = "eu-west-1"
+	}
+
+	results := runAudits(jobs, vpcs, policy.Default(), 2)
+
+	if len(results) != 3 {
+		t.Fatalf("runAudits() returned %d results, want 3", len(results))
+	}
+	for _, result := range results {
+		if result.Passed {
+			t.Errorf("result for %s passed, want a not-default-vpc finding", result.VPCID)
+		}
+	}
+}
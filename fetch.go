@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"guardian/vpc-nag/nagerr"
+	"guardian/vpc-nag/prism"
+)
+
+// fetchVPCs lists every VPC Prism knows about, translating the client's
+// sentinel errors into vpc-nag's nagerr taxonomy.
+func fetchVPCs(client *prism.Client) ([]prism.VPC, error) {
+	vpcs, err := client.ListVPCs(context.Background())
+	if err == nil {
+		return vpcs, nil
+	}
+
+	switch {
+	case errors.Is(err, prism.ErrPrismUnauthorized):
+		return nil, nagerr.Wrap(nagerr.Unauthenticated, "Prism rejected our credentials", err)
+	case errors.Is(err, prism.ErrPrismUnavailable):
+		return nil, nagerr.Wrap(nagerr.UpstreamUnavailable, "Prism is unavailable", err)
+	case errors.Is(err, prism.ErrPrismDecode):
+		return nil, nagerr.Wrap(nagerr.Internal, "could not decode Prism response", err)
+	default:
+		return nil, nagerr.Wrap(nagerr.Internal, "unable to list VPCs from Prism", err)
+	}
+}
@@ -1,124 +1,108 @@
 package main
 
 import (
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"guardian/vpc-nag/nagerr"
+	"guardian/vpc-nag/policy"
+	"guardian/vpc-nag/prism"
+	"guardian/vpc-nag/report"
 )
 
-type PrismSubnet struct {
-	AvailabilityZone        string `json:"availabilityZone"`
-	AvailableIPAddressCount int64  `json:"availableIpAddressCount"`
-	CapacityIPAddressCount  int64  `json:"capacityIpAddressCount"`
-	CidrBlock               string `json:"cidrBlock"`
-	OwnerID                 string `json:"ownerId"`
-	State                   string `json:"state"`
-	SubnetArn               string `json:"subnetArn"`
-	SubnetID                string `json:"subnetId"`
-	IsPublic                bool   `json:"isPublic"`
-}
-
-type PrismVPC struct {
-	VPCID     string            `json:"vpcId"`
-	AccountID string            `json:"accountId"`
-	State     string            `json:"state"`
-	IsDefault bool              `json:"default"`
-	Subnets   []PrismSubnet     `json:"subnets"`
-	Tags      map[string]string `json:"tags"`
-	Meta      struct {
-		Origin struct {
-			Region string `json:"region"`
-		} `json:"origin"`
-	} `json:"meta"`
-}
-
-type PrismResponse struct {
-	Data struct {
-		VPCs []PrismVPC `json:"vpcs"`
-	} `json:"data"`
-}
+// Exit codes. 0 means no error-severity findings and 1 means at least one;
+// the rest distinguish the ways vpc-nag itself can fail to run at all.
+const (
+	exitOK       = 0
+	exitFindings = 1
+	exitUsage    = 2
+	exitAuth     = 3
+	exitInternal = 4
+)
 
 func main() {
-	accountID := flag.String("accountID", "", "Specify account (ID) to audit.")
-	flag.Parse()
-
-	if *accountID == "" {
-		fmt.Println("Missing required argument: accountID")
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
 	}
 
-	resp, err := http.Get("https://prism.gutools.co.uk/vpcs")
-	check(err, "GET from PRISM failed")
+	var accountIDs stringSliceFlag
+	flag.Var(&accountIDs, "accountID", "Account ID to audit. Repeatable.")
+	accountsFile := flag.String("accountsFile", "", "Path to a newline-delimited file of account IDs to audit.")
+	allAccounts := flag.Bool("allAccounts", false, "Audit every account Prism knows about.")
+	regions := flag.String("regions", "eu-west-1", "Comma-separated list of regions to audit.")
+	concurrency := flag.Int("concurrency", 8, "Maximum number of (account, region) audits to run at once.")
+	policyPath := flag.String("policy", "", "Path to a YAML policy file. Defaults to the built-in policy.")
+	output := flag.String("output", "text", "Report format: text, json or sarif.")
+	prismURL := flag.String("prismURL", "", "Prism base URL. Defaults to Prism's production URL.")
+	debugFlag := flag.Bool("debug", false, "Print a stack trace for internal errors.")
+	flag.Parse()
 
-	data, err := io.ReadAll(resp.Body)
-	check(err, "unable to read prism response body")
-	defer resp.Body.Close()
+	pol, err := loadPolicy(*policyPath)
+	fail(err, *debugFlag)
 
-	prismResponse := PrismResponse{}
-	err = json.Unmarshal(data, &prismResponse)
-	check(err, "unable to unmarshal")
+	reporter, err := report.For(*output)
+	fail(err, *debugFlag)
 
-	accountVPCs := Filter(prismResponse.Data.VPCs, func(vpc PrismVPC) bool {
-		return vpc.AccountID == *accountID
-	})
+	vpcs, err := fetchVPCs(prism.NewClient(*prismURL))
+	fail(err, *debugFlag)
 
-	for _, vpc := range accountVPCs {
-		if vpc.AccountID != *accountID {
-			continue
-		}
+	accounts, err := resolveAccounts(accountIDs, *accountsFile, *allAccounts, vpcs)
+	fail(err, *debugFlag)
 
-		complianceErrs := checkCompliance(vpc)
-		if len(complianceErrs) > 0 {
-			reportCompliance(vpc, complianceErrs)
-		}
-	}
+	fail(validateConcurrency(*concurrency), *debugFlag)
 
-	nonEuWest1 := Filter(accountVPCs, func(vpc PrismVPC) bool {
-		return vpc.Meta.Origin.Region != "eu-west-1"
-	})
+	if len(accounts) == 0 {
+		fmt.Println("Missing required argument: accountID, accountsFile or allAccounts")
+		os.Exit(exitUsage)
+	}
 
-	if len(nonEuWest1) > 0 {
-		fmt.Printf("The following VPCs were ignored as are in non-standard regions:\n")
-		for _, vpc := range nonEuWest1 {
-			fmt.Printf("\t%s (%s)\n", vpc.VPCID, vpc.Meta.Origin.Region)
+	var jobs []auditJob
+	for _, accountID := range accounts {
+		for _, region := range parseRegions(*regions) {
+			jobs = append(jobs, auditJob{accountID: accountID, region: region})
 		}
 	}
 
-}
-
-func checkCompliance(vpc PrismVPC) []error {
-	errs := []error{}
+	results := runAudits(jobs, vpcs, pol, *concurrency)
 
-	if vpc.Meta.Origin.Region != "eu-west-1" {
-		return errs // ignore
-	}
+	fail(reporter.Report(os.Stdout, results), *debugFlag)
 
-	if vpc.IsDefault {
-		errs = append(errs, errors.New("is Default VPC"))
-		return errs // don't bother checking other errors
+	if hasErrorFindings(results) {
+		os.Exit(exitFindings)
 	}
+}
 
-	// has 3 public subnets and 3 private subnets
-	publicSubnets := Filter(vpc.Subnets, func(subnet PrismSubnet) bool {
-		return subnet.IsPublic
-	})
+// stringSliceFlag collects repeated occurrences of a flag into a slice, e.g.
+// -accountID 111 -accountID 222.
+type stringSliceFlag []string
 
-	privateSubnets := Filter(vpc.Subnets, func(subnet PrismSubnet) bool {
-		return !subnet.IsPublic
-	})
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
 
-	if len(publicSubnets) != 3 {
-		errs = append(errs, fmt.Errorf("expected 3 public subnets, found %d", len(publicSubnets)))
-	}
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
 
-	if len(privateSubnets) != 3 {
-		errs = append(errs, fmt.Errorf("expected 3 private subnets, found %d", len(privateSubnets)))
+// loadPolicy loads the policy at path, or falls back to the built-in
+// default policy when path is empty.
+func loadPolicy(path string) (*policy.Policy, error) {
+	if path == "" {
+		return policy.Default(), nil
 	}
+	return policy.Load(path)
+}
 
-	return errs
+// checkCompliance evaluates rules against vpc, returning one Finding per
+// violated rule.
+func checkCompliance(vpc prism.VPC, rules []policy.Rule) []policy.Finding {
+	return policy.Evaluate(policy.FromPrism(vpc), rules)
 }
 
 func Filter[A any](items []A, pred func(A) bool) []A {
@@ -133,18 +117,31 @@ func Filter[A any](items []A, pred func(A) bool) []A {
 	return out
 }
 
-func reportCompliance(vpc PrismVPC, errors []error) {
-	fmt.Printf("Failed: %s (%s)\n", vpc.VPCID, vpc.Meta.Origin.Region)
-
-	for _, err := range errors {
-		fmt.Printf("\t%s\n", err)
+// fail exits with a code and message suited to err's nagerr.Code, if it has
+// one. Internal errors print a stack trace when debug is set.
+func fail(err error, debugOn bool) {
+	if err == nil {
+		return
 	}
 
-	fmt.Println()
-}
-
-func check(err error, msg string) {
-	if err != nil {
-		log.Fatalf("%s: %v", msg, err)
+	var nerr *nagerr.NagError
+	if errors.As(err, &nerr) {
+		switch nerr.Code {
+		case nagerr.Unauthenticated:
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitAuth)
+		case nagerr.Internal:
+			fmt.Fprintln(os.Stderr, err)
+			if debugOn {
+				debug.PrintStack()
+			}
+			os.Exit(exitInternal)
+		case nagerr.ValidationFailed:
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
 	}
+
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(exitInternal)
 }
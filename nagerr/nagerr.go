@@ -0,0 +1,65 @@
+// Package nagerr defines vpc-nag's structured error taxonomy: a small set
+// of machine-readable codes that callers can switch on instead of matching
+// error strings.
+package nagerr
+
+import "fmt"
+
+// Code classifies why an operation failed.
+type Code string
+
+const (
+	// ValidationFailed means the input (flags, policy file, accounts
+	// file) was invalid.
+	ValidationFailed Code = "validation_failed"
+	// PolicyViolation means a resource failed a compliance rule.
+	PolicyViolation Code = "policy_violation"
+	// UpstreamUnavailable means a dependency (Prism) could not be
+	// reached or returned a transient error.
+	UpstreamUnavailable Code = "upstream_unavailable"
+	// Unauthenticated means a dependency rejected our credentials.
+	Unauthenticated Code = "unauthenticated"
+	// NotFound means a requested resource does not exist.
+	NotFound Code = "not_found"
+	// Internal means a bug or invariant violation in vpc-nag itself.
+	Internal Code = "internal"
+)
+
+// NagError is an error carrying a machine-readable Code alongside a
+// human-readable message and an optional underlying cause.
+type NagError struct {
+	Code  Code
+	Msg   string
+	Cause error
+}
+
+// New returns a NagError with no underlying cause.
+func New(code Code, msg string) *NagError {
+	return &NagError{Code: code, Msg: msg}
+}
+
+// Wrap returns a NagError of the given code that wraps cause.
+func Wrap(code Code, msg string, cause error) *NagError {
+	return &NagError{Code: code, Msg: msg, Cause: cause}
+}
+
+func (e *NagError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Msg, e.Cause)
+	}
+	return e.Msg
+}
+
+func (e *NagError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a *NagError with the same Code, so callers
+// can write errors.Is(err, nagerr.New(nagerr.Unauthenticated, "")).
+func (e *NagError) Is(target error) bool {
+	t, ok := target.(*NagError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
@@ -0,0 +1,37 @@
+package policy
+
+// Default returns the built-in policy used when vpc-nag is run without a
+// -policy flag. It reproduces the tool's original, hardcoded behaviour:
+// default VPCs are flagged outright, and every other VPC must have exactly
+// 3 public and 3 private subnets. Region scoping is no longer part of the
+// policy itself; it's handled by the caller's choice of which (account,
+// region) pairs to audit.
+func Default() *Policy {
+	return &Policy{
+		Rules: []Rule{
+			{
+				ID:         "not-default-vpc",
+				Target:     TargetVPC,
+				Check:      CheckNotDefault,
+				Severity:   SeverityError,
+				StopOnFail: true,
+			},
+			{
+				ID:         "public-subnet-count",
+				Target:     TargetVPC,
+				Check:      CheckSubnetCount,
+				Severity:   SeverityError,
+				Visibility: "public",
+				Count:      3,
+			},
+			{
+				ID:         "private-subnet-count",
+				Target:     TargetVPC,
+				Check:      CheckSubnetCount,
+				Severity:   SeverityError,
+				Visibility: "private",
+				Count:      3,
+			},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// VPC and Subnet are the minimal shapes Evaluate needs. They're distinct
+// from prism.VPC/prism.Subnet so Evaluate doesn't depend on the wire
+// format Prism happens to use; see FromPrism for the conversion.
+type VPC struct {
+	ID        string
+	AccountID string
+	Region    string
+	IsDefault bool
+	Tags      map[string]string
+	Subnets   []Subnet
+}
+
+type Subnet struct {
+	ARN                     string
+	AvailabilityZone        string
+	CidrBlock               string
+	AvailableIPAddressCount int64
+	IsPublic                bool
+}
+
+// Evaluate runs rules against vpc in order, returning one Finding per failed
+// rule. A failed rule with StopOnFail set ends evaluation early.
+func Evaluate(vpc VPC, rules []Rule) []Finding {
+	var findings []Finding
+
+	for _, rule := range rules {
+		switch rule.Target {
+		case TargetSubnet:
+			failed := false
+			for _, subnet := range vpc.Subnets {
+				if ok, msg := evalSubnetRule(rule, subnet); !ok {
+					failed = true
+					findings = append(findings, Finding{
+						RuleID:   rule.ID,
+						Severity: rule.Severity,
+						Message:  message(rule, msg),
+						Resource: subnet.ARN,
+					})
+				}
+			}
+			if failed && rule.StopOnFail {
+				return findings
+			}
+		default:
+			ok, msg := evalVPCRule(rule, vpc)
+			if ok {
+				continue
+			}
+			findings = append(findings, Finding{
+				RuleID:   rule.ID,
+				Severity: rule.Severity,
+				Message:  message(rule, msg),
+				Resource: vpc.ID,
+			})
+			if rule.StopOnFail {
+				return findings
+			}
+		}
+	}
+
+	return findings
+}
+
+func message(rule Rule, generated string) string {
+	if rule.Message != "" {
+		return rule.Message
+	}
+	return generated
+}
+
+func evalVPCRule(rule Rule, vpc VPC) (ok bool, msg string) {
+	switch rule.Check {
+	case CheckRegion:
+		if vpc.Region != rule.Region {
+			return false, fmt.Sprintf("VPC is in region %q, outside audited region %q", vpc.Region, rule.Region)
+		}
+		return true, ""
+
+	case CheckNotDefault:
+		if vpc.IsDefault {
+			return false, "is Default VPC"
+		}
+		return true, ""
+
+	case CheckTagPresent:
+		if _, ok := vpc.Tags[rule.TagKey]; !ok {
+			return false, fmt.Sprintf("missing required tag %q", rule.TagKey)
+		}
+		return true, ""
+
+	case CheckTagRegex:
+		re, err := compile(rule.TagRegex)
+		if err != nil {
+			return false, fmt.Sprintf("invalid tagRegex %q: %v", rule.TagRegex, err)
+		}
+		if !re.MatchString(vpc.Tags[rule.TagKey]) {
+			return false, fmt.Sprintf("tag %q value %q does not match %q", rule.TagKey, vpc.Tags[rule.TagKey], rule.TagRegex)
+		}
+		return true, ""
+
+	case CheckSubnetCount:
+		count := 0
+		for _, subnet := range vpc.Subnets {
+			if subnetMatchesVisibility(subnet, rule.Visibility) {
+				count++
+			}
+		}
+		if count != rule.Count {
+			return false, fmt.Sprintf("expected %d %s subnets, found %d", rule.Count, rule.Visibility, count)
+		}
+		return true, ""
+
+	case CheckAZSpread:
+		azs := map[string]struct{}{}
+		for _, subnet := range vpc.Subnets {
+			azs[subnet.AvailabilityZone] = struct{}{}
+		}
+		if len(azs) < rule.MinAZs {
+			return false, fmt.Sprintf("expected subnets spread across at least %d AZs, found %d", rule.MinAZs, len(azs))
+		}
+		return true, ""
+
+	default:
+		return true, ""
+	}
+}
+
+func evalSubnetRule(rule Rule, subnet Subnet) (ok bool, msg string) {
+	switch rule.Check {
+	case CheckCIDRContains:
+		_, cidr, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			return false, fmt.Sprintf("invalid cidrContains %q: %v", rule.CIDR, err)
+		}
+		ip, _, err := net.ParseCIDR(subnet.CidrBlock)
+		if err != nil {
+			return false, fmt.Sprintf("subnet has invalid CIDR %q: %v", subnet.CidrBlock, err)
+		}
+		if !cidr.Contains(ip) {
+			return false, fmt.Sprintf("subnet CIDR %s is not contained in %s", subnet.CidrBlock, rule.CIDR)
+		}
+		return true, ""
+
+	case CheckMinAvailableIPs:
+		if subnet.AvailableIPAddressCount < rule.MinAvailableIPs {
+			return false, fmt.Sprintf("only %d available IPs, want at least %d", subnet.AvailableIPAddressCount, rule.MinAvailableIPs)
+		}
+		return true, ""
+
+	default:
+		return true, ""
+	}
+}
+
+func subnetMatchesVisibility(subnet Subnet, visibility string) bool {
+	switch strings.ToLower(visibility) {
+	case "public":
+		return subnet.IsPublic
+	case "private":
+		return !subnet.IsPublic
+	default:
+		return false
+	}
+}
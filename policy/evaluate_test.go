@@ -0,0 +1,197 @@
+package policy
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name    string
+		vpc     VPC
+		rules   []Rule
+		wantIDs []string
+	}{
+		{
+			name: "region mismatch fails and stops evaluation",
+			vpc:  VPC{ID: "vpc-1", Region: "us-east-1"},
+			rules: []Rule{
+				{ID: "region", Target: TargetVPC, Check: CheckRegion, Region: "eu-west-1", StopOnFail: true},
+				{ID: "not-default", Target: TargetVPC, Check: CheckNotDefault, StopOnFail: true},
+			},
+			wantIDs: []string{"region"},
+		},
+		{
+			name: "region match passes",
+			vpc:  VPC{ID: "vpc-1", Region: "eu-west-1"},
+			rules: []Rule{
+				{ID: "region", Target: TargetVPC, Check: CheckRegion, Region: "eu-west-1", StopOnFail: true},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "default VPC fails",
+			vpc:  VPC{ID: "vpc-1", IsDefault: true},
+			rules: []Rule{
+				{ID: "not-default", Target: TargetVPC, Check: CheckNotDefault},
+			},
+			wantIDs: []string{"not-default"},
+		},
+		{
+			name: "tag present passes when tag exists",
+			vpc:  VPC{ID: "vpc-1", Tags: map[string]string{"Stack": "foo"}},
+			rules: []Rule{
+				{ID: "tag-present", Target: TargetVPC, Check: CheckTagPresent, TagKey: "Stack"},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "tag present fails when tag missing",
+			vpc:  VPC{ID: "vpc-1", Tags: map[string]string{}},
+			rules: []Rule{
+				{ID: "tag-present", Target: TargetVPC, Check: CheckTagPresent, TagKey: "Stack"},
+			},
+			wantIDs: []string{"tag-present"},
+		},
+		{
+			name: "tag regex fails when value doesn't match",
+			vpc:  VPC{ID: "vpc-1", Tags: map[string]string{"Stack": "foo"}},
+			rules: []Rule{
+				{ID: "tag-regex", Target: TargetVPC, Check: CheckTagRegex, TagKey: "Stack", TagRegex: "^bar$"},
+			},
+			wantIDs: []string{"tag-regex"},
+		},
+		{
+			name: "tag regex passes when value matches",
+			vpc:  VPC{ID: "vpc-1", Tags: map[string]string{"Stack": "foo"}},
+			rules: []Rule{
+				{ID: "tag-regex", Target: TargetVPC, Check: CheckTagRegex, TagKey: "Stack", TagRegex: "^fo+$"},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "subnet count fails when public count is wrong",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", IsPublic: true},
+				},
+			},
+			rules: []Rule{
+				{ID: "public-count", Target: TargetVPC, Check: CheckSubnetCount, Visibility: "public", Count: 3},
+			},
+			wantIDs: []string{"public-count"},
+		},
+		{
+			name: "subnet count passes when counts match",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", IsPublic: true},
+					{ARN: "b", IsPublic: false},
+				},
+			},
+			rules: []Rule{
+				{ID: "public-count", Target: TargetVPC, Check: CheckSubnetCount, Visibility: "public", Count: 1},
+				{ID: "private-count", Target: TargetVPC, Check: CheckSubnetCount, Visibility: "private", Count: 1},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "az spread fails when too few AZs",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", AvailabilityZone: "eu-west-1a"},
+					{ARN: "b", AvailabilityZone: "eu-west-1a"},
+				},
+			},
+			rules: []Rule{
+				{ID: "az-spread", Target: TargetVPC, Check: CheckAZSpread, MinAZs: 2},
+			},
+			wantIDs: []string{"az-spread"},
+		},
+		{
+			name: "cidr contains fails when subnet is outside the range",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", CidrBlock: "10.1.0.0/24"},
+				},
+			},
+			rules: []Rule{
+				{ID: "cidr", Target: TargetSubnet, Check: CheckCIDRContains, CIDR: "10.0.0.0/16"},
+			},
+			wantIDs: []string{"cidr"},
+		},
+		{
+			name: "cidr contains passes when subnet is inside the range",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", CidrBlock: "10.0.1.0/24"},
+				},
+			},
+			rules: []Rule{
+				{ID: "cidr", Target: TargetSubnet, Check: CheckCIDRContains, CIDR: "10.0.0.0/16"},
+			},
+			wantIDs: nil,
+		},
+		{
+			name: "min available IPs fails when below the threshold",
+			vpc: VPC{
+				ID: "vpc-1",
+				Subnets: []Subnet{
+					{ARN: "a", AvailableIPAddressCount: 2},
+				},
+			},
+			rules: []Rule{
+				{ID: "min-ips", Target: TargetSubnet, Check: CheckMinAvailableIPs, MinAvailableIPs: 10},
+			},
+			wantIDs: []string{"min-ips"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := Evaluate(tc.vpc, tc.rules)
+			gotIDs := make([]string, len(findings))
+			for i, f := range findings {
+				gotIDs[i] = f.RuleID
+			}
+
+			if len(gotIDs) != len(tc.wantIDs) {
+				t.Fatalf("Evaluate() findings = %v, want %v", gotIDs, tc.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tc.wantIDs[i] {
+					t.Fatalf("Evaluate() findings = %v, want %v", gotIDs, tc.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+// TestEvaluateConcurrentTagRegex exercises the regexCache from many
+// goroutines at once; run with -race to catch regressions of the data race
+// fixed in chunk0-1.
+func TestEvaluateConcurrentTagRegex(t *testing.T) {
+	rules := []Rule{
+		{ID: "tag-regex", Target: TargetVPC, Check: CheckTagRegex, TagKey: "Stack", TagRegex: "^stack-[0-9]+$"},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vpc := VPC{ID: "vpc-1", Tags: map[string]string{"Stack": "stack-1"}}
+			if i%2 == 0 {
+				vpc.Tags["Stack"] = "not-a-stack"
+			}
+			Evaluate(vpc, rules)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,135 @@
+// Package policy implements the vpc-nag rules engine: a set of declarative
+// checks loaded from an external config file and evaluated against the VPCs
+// and subnets returned by Prism.
+package policy
+
+import (
+	"os"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"guardian/vpc-nag/nagerr"
+)
+
+// Severity is how seriously a rule violation should be treated.
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// Target is the kind of resource a Rule is evaluated against.
+type Target string
+
+const (
+	TargetVPC    Target = "vpc"
+	TargetSubnet Target = "subnet"
+)
+
+// Check names the predicate a Rule applies. Only the Rule fields belonging to
+// this Check are consulted.
+type Check string
+
+const (
+	CheckRegion          Check = "region"
+	CheckNotDefault      Check = "notDefault"
+	CheckTagPresent      Check = "tagPresent"
+	CheckTagRegex        Check = "tagRegex"
+	CheckSubnetCount     Check = "subnetCount"
+	CheckCIDRContains    Check = "cidrContains"
+	CheckAZSpread        Check = "azSpread"
+	CheckMinAvailableIPs Check = "minAvailableIps"
+)
+
+// Rule is a single declarative check. Only the fields relevant to Check are
+// read; the rest are ignored.
+type Rule struct {
+	ID       string   `yaml:"id"`
+	Target   Target   `yaml:"target"`
+	Check    Check    `yaml:"check"`
+	Severity Severity `yaml:"severity"`
+	Message  string   `yaml:"message,omitempty"`
+
+	// StopOnFail, when the rule fails, skips the rules that follow it in
+	// the same Policy (used e.g. to stop auditing a VPC that's out of
+	// scope or already disqualified by an earlier check).
+	StopOnFail bool `yaml:"stopOnFail,omitempty"`
+
+	Region          string `yaml:"region,omitempty"`
+	TagKey          string `yaml:"tagKey,omitempty"`
+	TagRegex        string `yaml:"tagRegex,omitempty"`
+	Visibility      string `yaml:"visibility,omitempty"` // "public" or "private"
+	Count           int    `yaml:"count,omitempty"`
+	CIDR            string `yaml:"cidr,omitempty"`
+	MinAZs          int    `yaml:"minAZs,omitempty"`
+	MinAvailableIPs int64  `yaml:"minAvailableIps,omitempty"`
+}
+
+// Policy is the full set of rules applied to an audit, plus any per-account
+// overrides.
+type Policy struct {
+	Rules    []Rule                  `yaml:"rules"`
+	Accounts map[string]AccountRules `yaml:"accounts,omitempty"`
+}
+
+// AccountRules overrides the default rule set for a single AccountID.
+type AccountRules struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// RulesFor returns the rules that apply to accountID: its override set if
+// one is configured, otherwise the policy's default rules.
+func (p *Policy) RulesFor(accountID string) []Rule {
+	if override, ok := p.Accounts[accountID]; ok {
+		return override.Rules
+	}
+	return p.Rules
+}
+
+// Load reads and parses a Policy from a YAML file at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nagerr.Wrap(nagerr.ValidationFailed, "reading policy file "+path, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, nagerr.Wrap(nagerr.ValidationFailed, "parsing policy file "+path, err)
+	}
+
+	return &p, nil
+}
+
+// Finding is a single rule result produced by evaluating a Policy against a
+// VPC.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+	Resource string // VPC ID or subnet ARN the finding relates to
+}
+
+// regexCache caches regexes compiled during evaluation so repeated audits
+// don't recompile the same pattern per VPC. Evaluate is called concurrently
+// across vpc-nag's worker pool and, in serve mode, across HTTP requests, so
+// this must be safe for concurrent use.
+var regexCache sync.Map // pattern string -> *regexp.Regexp
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := regexCache.LoadOrStore(pattern, re)
+	return actual.(*regexp.Regexp), nil
+}
@@ -0,0 +1,27 @@
+package policy
+
+import "guardian/vpc-nag/prism"
+
+// FromPrism converts a prism.VPC into the VPC shape Evaluate expects,
+// decoupling rule evaluation from Prism's wire format.
+func FromPrism(vpc prism.VPC) VPC {
+	subnets := make([]Subnet, len(vpc.Subnets))
+	for i, s := range vpc.Subnets {
+		subnets[i] = Subnet{
+			ARN:                     s.SubnetArn,
+			AvailabilityZone:        s.AvailabilityZone,
+			CidrBlock:               s.CidrBlock,
+			AvailableIPAddressCount: s.AvailableIPAddressCount,
+			IsPublic:                s.IsPublic,
+		}
+	}
+
+	return VPC{
+		ID:        vpc.VPCID,
+		AccountID: vpc.AccountID,
+		Region:    vpc.Meta.Origin.Region,
+		IsDefault: vpc.IsDefault,
+		Tags:      vpc.Tags,
+		Subnets:   subnets,
+	}
+}
@@ -0,0 +1,30 @@
+package prism
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// jitter returns d plus up to 50% extra, to avoid retry storms when many
+// clients back off in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// sleep waits for d, returning early with ctx.Err() if ctx is cancelled
+// first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,224 @@
+// Package prism is a client for the Prism AWS resource inventory
+// (https://github.com/guardian/prism), used here to list VPCs and their
+// subnets for compliance auditing.
+package prism
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultBaseURL     = "https://prism.gutools.co.uk"
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 4
+	defaultBaseBackoff = 200 * time.Millisecond
+	maxPages           = 100
+)
+
+// Subnet is a single VPC subnet as reported by Prism.
+type Subnet struct {
+	AvailabilityZone        string `json:"availabilityZone"`
+	AvailableIPAddressCount int64  `json:"availableIpAddressCount"`
+	CapacityIPAddressCount  int64  `json:"capacityIpAddressCount"`
+	CidrBlock               string `json:"cidrBlock"`
+	OwnerID                 string `json:"ownerId"`
+	State                   string `json:"state"`
+	SubnetArn               string `json:"subnetArn"`
+	SubnetID                string `json:"subnetId"`
+	IsPublic                bool   `json:"isPublic"`
+}
+
+// VPC is a single VPC as reported by Prism.
+type VPC struct {
+	VPCID     string            `json:"vpcId"`
+	AccountID string            `json:"accountId"`
+	State     string            `json:"state"`
+	IsDefault bool              `json:"default"`
+	Subnets   []Subnet          `json:"subnets"`
+	Tags      map[string]string `json:"tags"`
+	Meta      struct {
+		Origin struct {
+			Region string `json:"region"`
+		} `json:"origin"`
+	} `json:"meta"`
+}
+
+type link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+type listResponse struct {
+	Data struct {
+		VPCs []VPC `json:"vpcs"`
+	} `json:"data"`
+	Meta struct {
+		Links []link `json:"links"`
+	} `json:"meta"`
+}
+
+func (r listResponse) nextPage() string {
+	for _, l := range r.Meta.Links {
+		if l.Rel == "next" {
+			return l.Href
+		}
+	}
+	return ""
+}
+
+// Client talks to a Prism instance over HTTP.
+type Client struct {
+	// BaseURL is the root of the Prism API, e.g. "https://prism.gutools.co.uk".
+	BaseURL string
+	// HTTPClient is the client used to make requests. Defaults to
+	// http.DefaultClient, injectable so callers can fake Prism in tests.
+	HTTPClient *http.Client
+	// Timeout bounds each individual HTTP request.
+	Timeout time.Duration
+	// MaxAttempts bounds how many times a request is retried after a
+	// transient failure, including the first attempt.
+	MaxAttempts int
+	// BaseBackoff is the starting delay for exponential backoff between
+	// retries; it doubles each attempt and is jittered by up to 50%.
+	BaseBackoff time.Duration
+	// AuthToken, when set, is sent as a bearer token on every request.
+	AuthToken string
+}
+
+// NewClient returns a Client pointed at baseURL with sensible defaults. Pass
+// "" to use Prism's production URL. The bearer token, if any, is read from
+// the PRISM_TOKEN environment variable.
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		BaseURL:     baseURL,
+		HTTPClient:  http.DefaultClient,
+		Timeout:     defaultTimeout,
+		MaxAttempts: defaultMaxAttempts,
+		BaseBackoff: defaultBaseBackoff,
+		AuthToken:   os.Getenv("PRISM_TOKEN"),
+	}
+}
+
+// ListVPCs fetches the VPCs Prism knows about, following pagination links
+// and retrying transient failures with exponential backoff and jitter. At
+// most maxPages pages are fetched; pages beyond that are not requested.
+func (c *Client) ListVPCs(ctx context.Context) ([]VPC, error) {
+	var vpcs []VPC
+
+	url := c.BaseURL + "/vpcs"
+	for page := 0; url != "" && page < maxPages; page++ {
+		resp, err := c.fetchPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		vpcs = append(vpcs, resp.Data.VPCs...)
+		url = resp.nextPage()
+	}
+
+	return vpcs, nil
+}
+
+// fetchPage fetches and decodes a single page, retrying on transient
+// failures.
+func (c *Client) fetchPage(ctx context.Context, url string) (listResponse, error) {
+	var lastErr error
+
+	attempts := c.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt)); err != nil {
+				return listResponse{}, err
+			}
+		}
+
+		resp, err := c.doFetchPage(ctx, url)
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+		if !isRetryable(err) {
+			return listResponse{}, err
+		}
+	}
+
+	return listResponse{}, lastErr
+}
+
+func (c *Client) doFetchPage(ctx context.Context, url string) (listResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return listResponse{}, fmt.Errorf("building Prism request: %w", err)
+	}
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return listResponse{}, fmt.Errorf("%w: %v", ErrPrismUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return listResponse{}, ErrPrismUnauthorized
+	case resp.StatusCode >= 500:
+		return listResponse{}, fmt.Errorf("%w: Prism returned %s", ErrPrismUnavailable, resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return listResponse{}, fmt.Errorf("Prism returned %s", resp.Status)
+	}
+
+	// Decode from the response body stream with json.Decoder rather than
+	// reading it first with io.ReadAll. The decoder still buffers one
+	// page's whole JSON document before decoding it.
+	var body listResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return listResponse{}, fmt.Errorf("%w: %v", ErrPrismDecode, err)
+	}
+
+	return body, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) requestTimeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return defaultTimeout
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	base := c.BaseBackoff
+	if base <= 0 {
+		base = defaultBaseBackoff
+	}
+	return jitter(base * time.Duration(int64(1)<<uint(attempt-1)))
+}
+
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrPrismUnavailable)
+}
@@ -0,0 +1,115 @@
+package prism
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestListVPCsRetriesTransientFailures(t *testing.T) {
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"vpcs":[{"vpcId":"vpc-1"}]}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.BaseBackoff = time.Millisecond
+
+	vpcs, err := client.ListVPCs(context.Background())
+	if err != nil {
+		t.Fatalf("ListVPCs() error = %v", err)
+	}
+	if len(vpcs) != 1 || vpcs[0].VPCID != "vpc-1" {
+		t.Fatalf("ListVPCs() = %v, want one VPC vpc-1", vpcs)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("server received %d requests, want 3", got)
+	}
+}
+
+func TestListVPCsGivesUpAfterMaxAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.BaseBackoff = time.Millisecond
+	client.MaxAttempts = 2
+
+	_, err := client.ListVPCs(context.Background())
+	if !errors.Is(err, ErrPrismUnavailable) {
+		t.Fatalf("ListVPCs() error = %v, want ErrPrismUnavailable", err)
+	}
+}
+
+func TestListVPCsUnauthorizedIsNotRetried(t *testing.T) {
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.BaseBackoff = time.Millisecond
+
+	_, err := client.ListVPCs(context.Background())
+	if !errors.Is(err, ErrPrismUnauthorized) {
+		t.Fatalf("ListVPCs() error = %v, want ErrPrismUnauthorized", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("server received %d requests, want exactly 1 (no retry)", got)
+	}
+}
+
+func TestListVPCsFollowsPagination(t *testing.T) {
+	var page2Hits int32
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/vpcs/page2", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&page2Hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"vpcs": [{"vpcId": "vpc-2"}]}}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/vpcs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"data": {"vpcs": [{"vpcId": "vpc-1"}]},
+			"meta": {"links": [{"rel": "next", "href": "` + srv.URL + `/vpcs/page2"}]}
+		}`))
+	})
+
+	client := NewClient(srv.URL)
+
+	vpcs, err := client.ListVPCs(context.Background())
+	if err != nil {
+		t.Fatalf("ListVPCs() error = %v", err)
+	}
+
+	if len(vpcs) != 2 {
+		t.Fatalf("ListVPCs() = %v, want 2 VPCs across both pages", vpcs)
+	}
+	if vpcs[0].VPCID != "vpc-1" || vpcs[1].VPCID != "vpc-2" {
+		t.Fatalf("ListVPCs() = %v, want [vpc-1 vpc-2]", vpcs)
+	}
+	if got := atomic.LoadInt32(&page2Hits); got != 1 {
+		t.Fatalf("page 2 was fetched %d times, want 1", got)
+	}
+}
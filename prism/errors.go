@@ -0,0 +1,14 @@
+package prism
+
+import "errors"
+
+var (
+	// ErrPrismUnavailable indicates a transient failure (network error or
+	// 5xx response) that's worth retrying.
+	ErrPrismUnavailable = errors.New("prism unavailable")
+	// ErrPrismUnauthorized indicates Prism rejected our credentials.
+	ErrPrismUnauthorized = errors.New("prism rejected credentials")
+	// ErrPrismDecode indicates Prism returned a response body vpc-nag
+	// couldn't parse.
+	ErrPrismDecode = errors.New("prism response could not be decoded")
+)
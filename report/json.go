@@ -0,0 +1,52 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONReporter emits a single JSON array holding one object per VPC result,
+// for consumption by CI pipelines and dashboards.
+type JSONReporter struct{}
+
+type jsonFinding struct {
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Resource string `json:"resource"`
+}
+
+type jsonResult struct {
+	VPCID     string        `json:"vpcId"`
+	AccountID string        `json:"accountId"`
+	Region    string        `json:"region"`
+	Passed    bool          `json:"passed"`
+	Findings  []jsonFinding `json:"findings"`
+}
+
+func (JSONReporter) Report(w io.Writer, results []Result) error {
+	out := make([]jsonResult, len(results))
+	for i, result := range results {
+		findings := make([]jsonFinding, len(result.Findings))
+		for j, f := range result.Findings {
+			findings[j] = jsonFinding{
+				RuleID:   f.RuleID,
+				Severity: string(f.Severity),
+				Message:  f.Message,
+				Resource: f.Resource,
+			}
+		}
+
+		out[i] = jsonResult{
+			VPCID:     result.VPCID,
+			AccountID: result.AccountID,
+			Region:    result.Region,
+			Passed:    result.Passed,
+			Findings:  findings,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,39 @@
+// Package report renders vpc-nag audit results for human and machine
+// consumption.
+package report
+
+import (
+	"io"
+
+	"guardian/vpc-nag/nagerr"
+	"guardian/vpc-nag/policy"
+)
+
+// Result is the outcome of auditing a single VPC.
+type Result struct {
+	VPCID     string
+	AccountID string
+	Region    string
+	Passed    bool
+	Findings  []policy.Finding
+}
+
+// Reporter renders a set of Results to w.
+type Reporter interface {
+	Report(w io.Writer, results []Result) error
+}
+
+// For returns the Reporter registered under format, one of "text", "json" or
+// "sarif".
+func For(format string) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return TextReporter{}, nil
+	case "json":
+		return JSONReporter{}, nil
+	case "sarif":
+		return SARIFReporter{}, nil
+	default:
+		return nil, nagerr.New(nagerr.ValidationFailed, "unknown output format "+format)
+	}
+}
@@ -0,0 +1,129 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"guardian/vpc-nag/policy"
+)
+
+func TestJSONReporterShape(t *testing.T) {
+	results := []Result{
+		{
+			VPCID:     "vpc-1",
+			AccountID: "111",
+			Region:    "eu-west-1",
+			Passed:    false,
+			Findings: []policy.Finding{
+				{RuleID: "not-default-vpc", Severity: policy.SeverityError, Message: "is Default VPC", Resource: "vpc-1"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Report(&buf, results); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if len(decoded) != 1 {
+		t.Fatalf("got %d results, want 1", len(decoded))
+	}
+	if decoded[0]["vpcId"] != "vpc-1" {
+		t.Fatalf("vpcId = %v, want vpc-1", decoded[0]["vpcId"])
+	}
+	findings, ok := decoded[0]["findings"].([]any)
+	if !ok || len(findings) != 1 {
+		t.Fatalf("findings = %v, want one finding", decoded[0]["findings"])
+	}
+}
+
+func TestJSONReporterEmptyFindingsIsArrayNotNull(t *testing.T) {
+	results := []Result{{VPCID: "vpc-1", Passed: true}}
+
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Report(&buf, results); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if _, ok := decoded[0]["findings"].([]any); !ok {
+		t.Fatalf("findings = %v (%T), want a JSON array", decoded[0]["findings"], decoded[0]["findings"])
+	}
+}
+
+func TestSARIFReporterEmptyResultsIsArrayNotNull(t *testing.T) {
+	results := []Result{{VPCID: "vpc-1", Passed: true}}
+
+	var buf bytes.Buffer
+	if err := (SARIFReporter{}).Report(&buf, results); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded struct {
+		Runs []struct {
+			Results []any `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if decoded.Runs[0].Results == nil {
+		t.Fatalf(`"results" was null, want an empty array for a run with no findings`)
+	}
+	if len(decoded.Runs[0].Results) != 0 {
+		t.Fatalf("results = %v, want empty", decoded.Runs[0].Results)
+	}
+}
+
+func TestSARIFReporterShape(t *testing.T) {
+	results := []Result{
+		{
+			VPCID: "vpc-1",
+			Findings: []policy.Finding{
+				{RuleID: "public-subnet-count", Severity: policy.SeverityError, Message: "expected 3 public subnets, found 1", Resource: "arn:aws:ec2:subnet/subnet-1"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (SARIFReporter{}).Report(&buf, results); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	var decoded struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Name string `json:"name"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if decoded.Runs[0].Tool.Driver.Name != "vpc-nag" {
+		t.Fatalf("driver name = %q, want vpc-nag", decoded.Runs[0].Tool.Driver.Name)
+	}
+	if len(decoded.Runs[0].Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(decoded.Runs[0].Results))
+	}
+	if decoded.Runs[0].Results[0].Level != "error" {
+		t.Fatalf("level = %q, want error", decoded.Runs[0].Results[0].Level)
+	}
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"guardian/vpc-nag/policy"
+)
+
+// SARIFReporter emits results as a SARIF 2.1.0 log, for consumption by code
+// scanning tools such as GitHub's.
+type SARIFReporter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+}
+
+func (SARIFReporter) Report(w io.Writer, results []Result) error {
+	sarifResults := []sarifResult{}
+
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:  finding.RuleID,
+				Level:   sarifLevel(finding.Severity),
+				Message: sarifMessage{Text: finding.Message},
+				Locations: []sarifLocation{{
+					LogicalLocations: []sarifLogicalLocation{{Name: resourceName(finding, result)}},
+				}},
+			})
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "vpc-nag"}},
+			Results: sarifResults,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func resourceName(finding policy.Finding, result Result) string {
+	if finding.Resource != "" {
+		return finding.Resource
+	}
+	return result.VPCID
+}
+
+func sarifLevel(severity policy.Severity) string {
+	switch severity {
+	case policy.SeverityError:
+		return "error"
+	case policy.SeverityWarn:
+		return "warning"
+	default:
+		return "note"
+	}
+}
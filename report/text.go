@@ -0,0 +1,26 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// TextReporter renders failed results as human-readable console output in
+// vpc-nag's original layout, with each finding prefixed by its severity.
+type TextReporter struct{}
+
+func (TextReporter) Report(w io.Writer, results []Result) error {
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+
+		fmt.Fprintf(w, "Failed: %s (%s)\n", result.VPCID, result.Region)
+		for _, finding := range result.Findings {
+			fmt.Fprintf(w, "\t[%s] %s\n", finding.Severity, finding.Message)
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
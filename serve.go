@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"guardian/vpc-nag/prism"
+	"guardian/vpc-nag/server"
+)
+
+// runServe implements the "serve" subcommand: vpc-nag as a long-lived HTTP
+// service instead of a one-shot CLI audit.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on.")
+	policyPath := fs.String("policy", "", "Path to a YAML policy file. Defaults to the built-in policy.")
+	prismURL := fs.String("prismURL", "", "Prism base URL. Defaults to Prism's production URL.")
+	cacheTTL := fs.Duration("cacheTTL", 5*time.Minute, "How long to cache Prism responses before refreshing.")
+	fs.Parse(args)
+
+	pol, err := loadPolicy(*policyPath)
+	if err != nil {
+		log.Fatalf("unable to load policy: %v", err)
+	}
+
+	srv := server.New(prism.NewClient(*prismURL), pol, *cacheTTL)
+
+	log.Printf("vpc-nag serve listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, srv.Handler()))
+}
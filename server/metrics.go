@@ -0,0 +1,129 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// prismLatencyBuckets are the histogram bucket boundaries, in seconds, for
+// vpc_nag_prism_request_duration_seconds.
+var prismLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type findingKey struct {
+	severity string
+	ruleID   string
+}
+
+// Metrics holds the counters and histogram exposed on /metrics in
+// Prometheus text exposition format.
+type Metrics struct {
+	mu            sync.Mutex
+	auditsTotal   int64
+	findingsTotal map[findingKey]int64
+	prismLatency  *histogram
+}
+
+// NewMetrics returns an empty Metrics ready to record observations.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		findingsTotal: map[findingKey]int64{},
+		prismLatency:  newHistogram(prismLatencyBuckets),
+	}
+}
+
+// RecordAudit increments vpc_nag_audits_total by one.
+func (m *Metrics) RecordAudit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.auditsTotal++
+}
+
+// RecordFinding increments vpc_nag_findings_total for the given severity and
+// rule ID.
+func (m *Metrics) RecordFinding(severity, ruleID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.findingsTotal[findingKey{severity: severity, ruleID: ruleID}]++
+}
+
+// ObservePrismLatency records how long a Prism request took.
+func (m *Metrics) ObservePrismLatency(d time.Duration) {
+	m.prismLatency.observe(d.Seconds())
+}
+
+// Render renders all metrics in Prometheus text exposition format.
+func (m *Metrics) Render(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP vpc_nag_audits_total Total number of account/region audits run.\n")
+	fmt.Fprintf(w, "# TYPE vpc_nag_audits_total counter\n")
+	fmt.Fprintf(w, "vpc_nag_audits_total %d\n", m.auditsTotal)
+
+	fmt.Fprintf(w, "# HELP vpc_nag_findings_total Total number of compliance findings, by severity and rule.\n")
+	fmt.Fprintf(w, "# TYPE vpc_nag_findings_total counter\n")
+	keys := make([]findingKey, 0, len(m.findingsTotal))
+	for k := range m.findingsTotal {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].severity != keys[j].severity {
+			return keys[i].severity < keys[j].severity
+		}
+		return keys[i].ruleID < keys[j].ruleID
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "vpc_nag_findings_total{severity=%q,rule_id=%q} %d\n", k.severity, k.ruleID, m.findingsTotal[k])
+	}
+
+	m.prismLatency.writeTo(w, "vpc_nag_prism_request_duration_seconds")
+
+	return nil
+}
+
+// histogram is a minimal cumulative-bucket histogram, as used by Prometheus
+// client libraries, without pulling in a dependency for just this.
+type histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []int64
+	sum     float64
+	count   int64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)),
+	}
+}
+
+func (h *histogram) observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sum += v
+	h.count++
+	for i, bound := range h.buckets {
+		if v <= bound {
+			h.counts[i]++
+		}
+	}
+}
+
+func (h *histogram) writeTo(w io.Writer, name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s Prism request latency in seconds.\n", name)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for i, bound := range h.buckets {
+		fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", name, fmt.Sprintf("%g", bound), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+	fmt.Fprintf(w, "%s_sum %g\n", name, h.sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, h.count)
+}
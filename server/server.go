@@ -0,0 +1,165 @@
+// Package server runs vpc-nag as a long-lived HTTP service: a compliance
+// API backed by a TTL-cached Prism client, alongside health and metrics
+// endpoints for operating it.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"guardian/vpc-nag/policy"
+	"guardian/vpc-nag/prism"
+	"guardian/vpc-nag/report"
+)
+
+// Server serves vpc-nag's HTTP API.
+type Server struct {
+	prism    *prism.Client
+	policy   *policy.Policy
+	cacheTTL time.Duration
+	metrics  *Metrics
+
+	mu       sync.Mutex
+	cached   []prism.VPC
+	cachedAt time.Time
+}
+
+// New returns a Server that audits against pol, fetching VPCs from
+// prismClient and caching them for cacheTTL.
+func New(prismClient *prism.Client, pol *policy.Policy, cacheTTL time.Duration) *Server {
+	return &Server{
+		prism:    prismClient,
+		policy:   pol,
+		cacheTTL: cacheTTL,
+		metrics:  NewMetrics(),
+	}
+}
+
+// Handler returns the http.Handler serving all of vpc-nag's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.HandleFunc("/metrics", s.handleMetrics)
+	mux.HandleFunc("/audit", s.handleAudit)
+	mux.HandleFunc("/vpcs/", s.handleCompliance)
+	return mux
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := s.vpcs(r.Context()); err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok"))
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.Render(w)
+}
+
+// handleAudit forces a cache refresh and reports whether it succeeded.
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, _, err := s.refresh(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleCompliance serves GET /vpcs/{accountId}/compliance.
+func (s *Server) handleCompliance(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := accountIDFromPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	vpcs, _, err := s.vpcs(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	rules := s.policy.RulesFor(accountID)
+
+	results := []report.Result{}
+	for _, vpc := range vpcs {
+		if vpc.AccountID != accountID {
+			continue
+		}
+		findings := policy.Evaluate(policy.FromPrism(vpc), rules)
+		for _, f := range findings {
+			s.metrics.RecordFinding(string(f.Severity), f.RuleID)
+		}
+		results = append(results, report.Result{
+			VPCID:     vpc.VPCID,
+			AccountID: vpc.AccountID,
+			Region:    vpc.Meta.Origin.Region,
+			Passed:    len(findings) == 0,
+			Findings:  findings,
+		})
+	}
+	s.metrics.RecordAudit()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// accountIDFromPath extracts {accountId} from "/vpcs/{accountId}/compliance".
+func accountIDFromPath(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "vpcs" || parts[2] != "compliance" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// vpcs returns the cached VPC listing, refreshing it if it's older than
+// cacheTTL.
+func (s *Server) vpcs(ctx context.Context) ([]prism.VPC, time.Time, error) {
+	s.mu.Lock()
+	stale := time.Since(s.cachedAt) > s.cacheTTL
+	cached, cachedAt := s.cached, s.cachedAt
+	s.mu.Unlock()
+
+	if !cachedAt.IsZero() && !stale {
+		return cached, cachedAt, nil
+	}
+
+	return s.refresh(ctx)
+}
+
+func (s *Server) refresh(ctx context.Context) ([]prism.VPC, time.Time, error) {
+	start := time.Now()
+	vpcs, err := s.prism.ListVPCs(ctx)
+	s.metrics.ObservePrismLatency(time.Since(start))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	s.cached, s.cachedAt = vpcs, now
+	s.mu.Unlock()
+
+	return vpcs, now, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"guardian/vpc-nag/policy"
+	"guardian/vpc-nag/prism"
+)
+
+func TestHandleComplianceEmptyAccountReturnsEmptyArrayNotNull(t *testing.T) {
+	fakePrism := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"vpcs":[]}}`))
+	}))
+	defer fakePrism.Close()
+
+	srv := New(prism.NewClient(fakePrism.URL), policy.Default(), time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/vpcs/123456789/compliance", nil)
+	rec := httptest.NewRecorder()
+
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body: %s", rec.Code, rec.Body.String())
+	}
+
+	body := rec.Body.Bytes()
+
+	var decoded []json.RawMessage
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("response is not a JSON array: %v\n%s", err, body)
+	}
+	if len(decoded) != 0 {
+		t.Fatalf("got %d results, want 0", len(decoded))
+	}
+
+	const nullBody = "null\n"
+	if string(body) == nullBody {
+		t.Fatalf(`response body was literal "null", want "[]"`)
+	}
+}
+
+func TestHandleComplianceReturnsFindingsForMatchingAccount(t *testing.T) {
+	fakePrism := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"vpcs":[{"vpcId":"vpc-1","accountId":"123456789","default":true}]}}`))
+	}))
+	defer fakePrism.Close()
+
+	srv := New(prism.NewClient(fakePrism.URL), policy.Default(), time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/vpcs/123456789/compliance", nil)
+	rec := httptest.NewRecorder()
+
+	srv.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body: %s", rec.Code, rec.Body.String())
+	}
+
+	var decoded []struct {
+		VPCID  string `json:"vpcId"`
+		Passed bool   `json:"passed"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("response is not valid JSON: %v\n%s", err, rec.Body.String())
+	}
+	if len(decoded) != 1 || decoded[0].VPCID != "vpc-1" || decoded[0].Passed {
+		t.Fatalf("got %+v, want one failing result for vpc-1", decoded)
+	}
+}